@@ -0,0 +1,231 @@
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what happens when the async queue is full.
+type OverflowPolicy int
+
+const (
+	// Block makes the caller wait until there's room in the queue.
+	Block OverflowPolicy = iota
+	// DropOldest evicts the oldest queued entry to make room for the new one.
+	DropOldest
+	// DropNewest discards the entry that just failed to enqueue.
+	DropNewest
+)
+
+const defaultFlushInterval = 200 * time.Millisecond
+
+// SetAsync routes subsequent log calls through a background goroutine
+// instead of writing inline. Entries are queued on a channel of size
+// bufferSize; overflow decides what happens once it's full. The worker
+// coalesces writes through a bufio.Writer, flushed every flush interval,
+// Flush(), or Close(). FATAL entries always flush and write synchronously
+// so the process doesn't exit before they're visible.
+func (l *Logger) SetAsync(bufferSize int, overflow OverflowPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.async {
+		return
+	}
+
+	l.async = true
+	l.overflowPolicy = overflow
+	l.asyncCh = make(chan *Entry, bufferSize)
+	l.asyncDone = make(chan struct{})
+	l.asyncClosed = false
+	l.entryPool = sync.Pool{New: func() interface{} { return &Entry{} }}
+	l.bufWriter = bufio.NewWriter(l.output)
+
+	go l.asyncWriter()
+}
+
+// Flush drains any entries currently queued and flushes the buffered
+// writer. It's a no-op when async logging isn't enabled.
+func (l *Logger) Flush() {
+	if !l.isAsync() {
+		return
+	}
+
+	for {
+		select {
+		case entry, ok := <-l.asyncCh:
+			if !ok {
+				l.flushBuffer()
+				return
+			}
+			l.formatAndBuffer(entry)
+		default:
+			l.flushBuffer()
+			return
+		}
+	}
+}
+
+// Close stops the async worker, flushing everything queued first. It's a
+// no-op when async logging isn't enabled.
+func (l *Logger) Close() error {
+	if !l.isAsync() {
+		return nil
+	}
+
+	l.shutdownAsync()
+	return nil
+}
+
+// shutdownAsync closes the async channel and waits for the worker to drain
+// and exit. It's idempotent and safe to call concurrently with in-flight
+// enqueue calls: shutdownMu ensures any send that started before the close
+// finishes before the channel is actually closed, and any enqueue that
+// starts afterward sees asyncClosed and falls back to a synchronous write
+// instead of sending on a closed channel.
+func (l *Logger) shutdownAsync() {
+	l.shutdownMu.Lock()
+	if l.asyncClosed {
+		l.shutdownMu.Unlock()
+		return
+	}
+	l.asyncClosed = true
+	close(l.asyncCh)
+	l.shutdownMu.Unlock()
+
+	<-l.asyncDone
+
+	l.mu.Lock()
+	l.async = false
+	l.mu.Unlock()
+}
+
+// DroppedCount returns the number of entries discarded under DropOldest or
+// DropNewest since SetAsync was called.
+func (l *Logger) DroppedCount() int64 {
+	return atomic.LoadInt64(&l.dropped)
+}
+
+func (l *Logger) isAsync() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.async
+}
+
+// enqueue applies the overflow policy and pushes entry onto the async
+// channel; it's only called once write() has already confirmed async mode.
+// Holding shutdownMu for read for the duration of the send guarantees it
+// never races a concurrent shutdownAsync closing the channel underneath it.
+func (l *Logger) enqueue(entry *Entry) {
+	l.shutdownMu.RLock()
+	defer l.shutdownMu.RUnlock()
+
+	if l.asyncClosed {
+		// The worker is gone (or going); fall back to a synchronous write
+		// rather than sending on a channel that's being closed.
+		l.writeSync(entry)
+		l.releaseEntry(entry)
+		return
+	}
+
+	switch l.overflowPolicy {
+	case DropNewest:
+		select {
+		case l.asyncCh <- entry:
+		default:
+			atomic.AddInt64(&l.dropped, 1)
+			l.releaseEntry(entry)
+		}
+	case DropOldest:
+		for {
+			select {
+			case l.asyncCh <- entry:
+				return
+			default:
+			}
+			select {
+			case oldest := <-l.asyncCh:
+				atomic.AddInt64(&l.dropped, 1)
+				l.releaseEntry(oldest)
+			default:
+			}
+		}
+	default: // Block
+		l.asyncCh <- entry
+	}
+}
+
+// asyncWriter drains the queue on its own goroutine, formatting and writing
+// each entry through a bufio.Writer that's flushed on an interval.
+func (l *Logger) asyncWriter() {
+	ticker := time.NewTicker(defaultFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry, ok := <-l.asyncCh:
+			if !ok {
+				l.flushBuffer()
+				close(l.asyncDone)
+				return
+			}
+			l.formatAndBuffer(entry)
+		case <-ticker.C:
+			l.flushBuffer()
+		}
+	}
+}
+
+// formatAndBuffer formats entry, fires hooks and writes the result to the
+// buffered writer, returning the entry to the pool afterward.
+func (l *Logger) formatAndBuffer(entry *Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	defer l.releaseEntry(entry)
+
+	formatted, err := l.formatter.Format(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting log: %v\n", err)
+		return
+	}
+
+	l.hooks.fire(entry.Level, entry)
+
+	if _, err := l.bufWriter.Write(formatted); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing log: %v\n", err)
+	}
+}
+
+func (l *Logger) flushBuffer() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.bufWriter != nil {
+		l.bufWriter.Flush()
+	}
+}
+
+// newEntry returns a pooled *Entry when async logging is enabled (so the
+// hot path allocates nothing) or a fresh one otherwise.
+func (l *Logger) newEntry() *Entry {
+	l.mu.Lock()
+	async := l.async
+	l.mu.Unlock()
+
+	if async {
+		return l.entryPool.Get().(*Entry)
+	}
+	return &Entry{}
+}
+
+// releaseEntry resets entry and returns it to the pool. Callers only reach
+// this once the entry has been fully written, and only along the async
+// code paths. Not every entry released here came from the pool (Entry.log
+// allocates its own); putting those into the pool is harmless.
+func (l *Logger) releaseEntry(entry *Entry) {
+	*entry = Entry{}
+	l.entryPool.Put(entry)
+}
@@ -0,0 +1,98 @@
+package logs
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	minimumCallerDepth = 1
+	maximumCallerDepth = 25
+)
+
+// logsPackage is this package's path as it appears in a runtime.Frame's
+// Function field, discovered once at init so SetReportCaller can skip over
+// the logger's own frames.
+var logsPackage string
+
+var skipPackagesMu sync.RWMutex
+var skipPackages = map[string]bool{}
+
+func init() {
+	pcs := make([]uintptr, 2)
+	// Skip 1 to land on this init function's own frame rather than
+	// runtime.Callers' (skip 0 would report package "runtime").
+	runtime.Callers(1, pcs)
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	logsPackage = getPackageName(frame.Function)
+}
+
+// RegisterCallerSkipPackage marks pkg, as it appears in a runtime.Frame's
+// Function field (e.g. "github.com/acme/mypkg/logwrap"), as a logging
+// wrapper package. SetReportCaller skips over frames in registered
+// packages and reports the real call site instead.
+func RegisterCallerSkipPackage(pkg string) {
+	skipPackagesMu.Lock()
+	defer skipPackagesMu.Unlock()
+	skipPackages[pkg] = true
+}
+
+func isSkipPackage(pkg string) bool {
+	if pkg == logsPackage {
+		return true
+	}
+	skipPackagesMu.RLock()
+	defer skipPackagesMu.RUnlock()
+	return skipPackages[pkg]
+}
+
+// getPackageName reduces a fully-qualified function name, as reported by
+// runtime.Frame.Function, to its package path.
+func getPackageName(f string) string {
+	for {
+		lastPeriod := strings.LastIndex(f, ".")
+		lastSlash := strings.LastIndex(f, "/")
+		if lastPeriod > lastSlash {
+			f = f[:lastPeriod]
+		} else {
+			break
+		}
+	}
+	return f
+}
+
+// callerPCPool recycles the []uintptr buffers getCaller feeds to
+// runtime.Callers so reporting the caller doesn't allocate a fresh PC
+// buffer on every call. The returned *runtime.Frame is still allocated
+// per call.
+var callerPCPool = sync.Pool{
+	New: func() interface{} {
+		pcs := make([]uintptr, maximumCallerDepth)
+		return &pcs
+	},
+}
+
+// getCaller walks the call stack, skipping frames that belong to this
+// package or to packages registered via RegisterCallerSkipPackage, and
+// returns the first frame that looks like user code.
+func getCaller() *runtime.Frame {
+	pcsPtr := callerPCPool.Get().(*[]uintptr)
+	defer callerPCPool.Put(pcsPtr)
+	pcs := *pcsPtr
+
+	depth := runtime.Callers(minimumCallerDepth, pcs)
+	frames := runtime.CallersFrames(pcs[:depth])
+
+	for {
+		frame, more := frames.Next()
+		if !isSkipPackage(getPackageName(frame.Function)) {
+			f := frame
+			return &f
+		}
+		if !more {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package logs_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	logs "github.com/InfinityXOneSystems/logs"
+)
+
+// This test lives in package logs_test, rather than alongside the rest of
+// the internal tests, because SetReportCaller skips frames that belong to
+// package logs itself: calling Info from within package logs would always
+// report the test runner, not the call site under test.
+func TestLoggerReportCallerReportsExternalCallSite(t *testing.T) {
+	logger := logs.New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(logs.DEBUG)
+	logger.SetReportCaller(true)
+
+	formatter := logs.NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+
+	logger.Info("with caller") // this line's number is asserted below
+
+	output := buf.String()
+	if !strings.Contains(output, "caller_external_test.go") {
+		t.Errorf("Expected output to report this file, got: %s", output)
+	}
+	if !strings.Contains(output, "TestLoggerReportCallerReportsExternalCallSite") {
+		t.Errorf("Expected output to report the calling function, got: %s", output)
+	}
+	if !strings.Contains(output, "line=26") {
+		t.Errorf("Expected output to report the Info call's line number, got: %s", output)
+	}
+}
@@ -0,0 +1,40 @@
+package logs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLoggerReportCallerDisabledByDefault(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+
+	formatter := NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+
+	logger.Info("no caller")
+
+	if strings.Contains(buf.String(), "caller_test.go") {
+		t.Errorf("Expected no caller info without SetReportCaller, got: %s", buf.String())
+	}
+}
+
+func TestGetPackageName(t *testing.T) {
+	tests := []struct {
+		function string
+		expected string
+	}{
+		{"github.com/InfinityXOneSystems/logs.(*Logger).Info", "github.com/InfinityXOneSystems/logs"},
+		{"github.com/InfinityXOneSystems/logs/hooks.NewFileHook", "github.com/InfinityXOneSystems/logs/hooks"},
+	}
+
+	for _, tt := range tests {
+		if got := getPackageName(tt.function); got != tt.expected {
+			t.Errorf("getPackageName(%q) = %q, want %q", tt.function, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package color detects whether an output writer can render ANSI color
+// escape sequences, enabling Windows' virtual terminal processing when
+// needed so console output there isn't garbled.
+package color
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Supported reports whether w is a terminal that will render ANSI color
+// codes. On Windows consoles that require it, this also turns on
+// ENABLE_VIRTUAL_TERMINAL_PROCESSING as a side effect of the check.
+func Supported(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if !term.IsTerminal(int(f.Fd())) {
+		return false
+	}
+	return enableVirtualTerminal(f)
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package color
+
+import "os"
+
+// enableVirtualTerminal is a no-op outside Windows: every other supported
+// terminal already renders ANSI escape sequences natively.
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
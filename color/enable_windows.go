@@ -0,0 +1,24 @@
+//go:build windows
+
+package color
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal turns on ENABLE_VIRTUAL_TERMINAL_PROCESSING for f's
+// console so ANSI escape sequences render instead of showing as garbage on
+// legacy Windows consoles.
+func enableVirtualTerminal(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+
+	mode |= windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	return windows.SetConsoleMode(handle, mode) == nil
+}
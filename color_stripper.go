@@ -0,0 +1,29 @@
+package logs
+
+import (
+	"io"
+	"regexp"
+)
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// colorStripper wraps an io.Writer, removing ANSI color escape sequences
+// from anything written to it.
+type colorStripper struct {
+	w io.Writer
+}
+
+// NewColorStripper wraps w so ANSI color escape sequences are removed
+// before writing through. Pairs naturally with io.MultiWriter: keep colors
+// on the console leg, strip them for a file leg written alongside it.
+func NewColorStripper(w io.Writer) io.Writer {
+	return &colorStripper{w: w}
+}
+
+// Write implements io.Writer.
+func (s *colorStripper) Write(p []byte) (int, error) {
+	if _, err := s.w.Write(ansiEscape.ReplaceAll(p, nil)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,151 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+)
+
+// ContextFieldExtractor pulls loggable fields out of a context.Context,
+// e.g. a request ID or tenant stashed there by middleware. Register one
+// with Logger.SetContextFieldExtractor so Entry.WithContext can use it.
+type ContextFieldExtractor func(ctx context.Context) Fields
+
+// SetContextFieldExtractor registers the function used to pull fields out
+// of a context.Context passed to Entry.WithContext.
+func (l *Logger) SetContextFieldExtractor(fn ContextFieldExtractor) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ctxExtractor = fn
+}
+
+func (l *Logger) contextFields(ctx context.Context) Fields {
+	l.mu.Lock()
+	extractor := l.ctxExtractor
+	l.mu.Unlock()
+
+	if extractor == nil || ctx == nil {
+		return nil
+	}
+	return extractor(ctx)
+}
+
+// WithField returns a child logger carrying key=value alongside whatever
+// message it's later given, e.g. logger.WithField("request_id", id).
+func (l *Logger) WithField(key string, value interface{}) *Entry {
+	return l.WithFields(Fields{key: value})
+}
+
+// WithFields returns a child logger carrying fields alongside whatever
+// message it's later given.
+func (l *Logger) WithFields(fields Fields) *Entry {
+	merged := make(Fields, len(fields))
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Entry{logger: l, Fields: merged}
+}
+
+// WithField returns a new Entry with key=value merged in, overriding any
+// existing value for key. The receiver is left unchanged.
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return e.WithFields(Fields{key: value})
+}
+
+// WithFields returns a new Entry with fields merged in, overriding any
+// existing values with the same keys. The receiver is left unchanged.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	merged := make(Fields, len(e.Fields)+len(fields))
+	for k, v := range e.Fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Entry{logger: e.logger, ctx: e.ctx, Fields: merged}
+}
+
+// WithContext attaches ctx to the entry so its fields are pulled in via the
+// logger's ContextFieldExtractor (see SetContextFieldExtractor) when the
+// entry is logged.
+func (e *Entry) WithContext(ctx context.Context) *Entry {
+	return &Entry{logger: e.logger, ctx: ctx, Fields: e.Fields}
+}
+
+// mergedFields combines the entry's own fields with whatever the logger's
+// ContextFieldExtractor pulls out of ctx, with context fields taking
+// precedence since they're the most specific to this call.
+func (e *Entry) mergedFields() Fields {
+	ctxFields := e.logger.contextFields(e.ctx)
+	if len(ctxFields) == 0 {
+		return e.Fields
+	}
+
+	merged := make(Fields, len(e.Fields)+len(ctxFields))
+	for k, v := range e.Fields {
+		merged[k] = v
+	}
+	for k, v := range ctxFields {
+		merged[k] = v
+	}
+	return merged
+}
+
+// log dispatches msg through the parent logger with this entry's fields.
+func (e *Entry) log(level Level, msg string) {
+	e.logger.write(&Entry{
+		Level:   level,
+		Message: msg,
+		Fields:  e.mergedFields(),
+		Prefix:  e.logger.prefix,
+	})
+}
+
+// Debug logs a debug message with the entry's fields.
+func (e *Entry) Debug(msg string) {
+	e.log(DEBUG, msg)
+}
+
+// Debugf logs a formatted debug message with the entry's fields.
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.log(DEBUG, fmt.Sprintf(format, args...))
+}
+
+// Info logs an info message with the entry's fields.
+func (e *Entry) Info(msg string) {
+	e.log(INFO, msg)
+}
+
+// Infof logs a formatted info message with the entry's fields.
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.log(INFO, fmt.Sprintf(format, args...))
+}
+
+// Warn logs a warning message with the entry's fields.
+func (e *Entry) Warn(msg string) {
+	e.log(WARN, msg)
+}
+
+// Warnf logs a formatted warning message with the entry's fields.
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.log(WARN, fmt.Sprintf(format, args...))
+}
+
+// Error logs an error message with the entry's fields.
+func (e *Entry) Error(msg string) {
+	e.log(ERROR, msg)
+}
+
+// Errorf logs a formatted error message with the entry's fields.
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.log(ERROR, fmt.Sprintf(format, args...))
+}
+
+// Fatal logs a fatal message with the entry's fields and exits.
+func (e *Entry) Fatal(msg string) {
+	e.log(FATAL, msg)
+}
+
+// Fatalf logs a formatted fatal message with the entry's fields and exits.
+func (e *Entry) Fatalf(format string, args ...interface{}) {
+	e.log(FATAL, fmt.Sprintf(format, args...))
+}
@@ -5,18 +5,23 @@ import (
 	"os"
 
 	"github.com/InfinityXOneSystems/logs"
+	"github.com/InfinityXOneSystems/logs/rotate"
 )
 
 func main() {
-	// Example: Multi-output logging (console and file simultaneously)
-	file, err := os.Create("combined.log")
+	// Example: Multi-output logging (console and a rotating file simultaneously)
+	file, err := rotate.NewRotatingFileWriter("combined.log")
 	if err != nil {
 		logs.Fatal("Failed to create log file")
 	}
+	file.MaxSizeBytes = 50 * 1024 * 1024 // rotate at 50MB
+	file.MaxBackups = 5
+	file.Compress = true
 	defer file.Close()
 
-	// Create a multi-writer that writes to both stdout and file
-	multiWriter := io.MultiWriter(os.Stdout, file)
+	// Create a multi-writer that writes colored output to the console and
+	// plain text (via NewColorStripper) to the file
+	multiWriter := io.MultiWriter(os.Stdout, logs.NewColorStripper(file))
 
 	logger := logs.New()
 	logger.SetOutput(multiWriter)
@@ -24,7 +29,7 @@ func main() {
 	logger.SetPrefix("MultiApp")
 
 	formatter := logs.NewTextFormatter()
-	formatter.DisableColors = false // Colors for console (file will show codes)
+	formatter.ForceColors = true // multiWriter isn't a terminal itself, so force it on
 	logger.SetFormatter(formatter)
 
 	// Demonstrate various logging scenarios
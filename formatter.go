@@ -1,21 +1,34 @@
 package logs
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"runtime"
 	"time"
+
+	"github.com/InfinityXOneSystems/logs/color"
 )
 
 // Fields represents additional fields to include in a log entry
 type Fields map[string]interface{}
 
-// Entry represents a log entry
+// Entry represents a log entry. It also serves as the contextual logger
+// returned by Logger.WithField/WithFields (see entry.go): in that role
+// logger and ctx carry the state needed to dispatch Info/Error/... calls,
+// while Fields holds the accumulated key/value pairs.
 type Entry struct {
 	Time    time.Time
 	Level   Level
 	Message string
 	Fields  Fields
 	Prefix  string
+
+	// Caller holds the file, line and function of the call site when the
+	// logger has SetReportCaller(true); nil otherwise.
+	Caller *runtime.Frame
+
+	logger *Logger
+	ctx    context.Context
 }
 
 // Formatter is the interface for formatting log entries
@@ -26,7 +39,16 @@ type Formatter interface {
 // TextFormatter formats log entries as human-readable text
 type TextFormatter struct {
 	TimestampFormat string
-	DisableColors   bool
+
+	// DisableColors always turns ANSI colors off, regardless of the
+	// output writer.
+	DisableColors bool
+
+	// ForceColors always turns ANSI colors on, regardless of whether the
+	// output writer looks like a color-capable terminal. Takes
+	// precedence over the auto-detection below but not over
+	// DisableColors.
+	ForceColors bool
 }
 
 // NewTextFormatter creates a new text formatter with default settings
@@ -42,7 +64,7 @@ func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
 	timestamp := entry.Time.Format(f.TimestampFormat)
 	level := entry.Level.String()
 
-	if !f.DisableColors {
+	if f.useColors(entry) {
 		level = colorizeLevel(entry.Level)
 	}
 
@@ -53,6 +75,10 @@ func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
 		msg = fmt.Sprintf("[%s] %s %s", timestamp, level, entry.Message)
 	}
 
+	if entry.Caller != nil {
+		msg += fmt.Sprintf(" func=%s file=%s line=%d", entry.Caller.Function, entry.Caller.File, entry.Caller.Line)
+	}
+
 	if len(entry.Fields) > 0 {
 		for k, v := range entry.Fields {
 			msg += fmt.Sprintf(" %s=%v", k, v)
@@ -63,6 +89,23 @@ func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
 	return []byte(msg), nil
 }
 
+// useColors decides whether this Format call should emit ANSI color codes.
+// DisableColors always wins, ForceColors always wins over auto-detection,
+// and otherwise colors are only emitted if the logger's output is a
+// terminal that actually renders them (so files and pipes stay clean).
+func (f *TextFormatter) useColors(entry *Entry) bool {
+	if f.DisableColors {
+		return false
+	}
+	if f.ForceColors {
+		return true
+	}
+	if entry.logger == nil {
+		return false
+	}
+	return color.Supported(entry.logger.output)
+}
+
 // colorizeLevel adds ANSI color codes to log levels
 func colorizeLevel(level Level) string {
 	const (
@@ -88,49 +131,3 @@ func colorizeLevel(level Level) string {
 		return level.String()
 	}
 }
-
-// JSONFormatter formats log entries as JSON
-type JSONFormatter struct {
-	PrettyPrint bool
-}
-
-// NewJSONFormatter creates a new JSON formatter
-func NewJSONFormatter() *JSONFormatter {
-	return &JSONFormatter{
-		PrettyPrint: false,
-	}
-}
-
-// Format formats a log entry as JSON
-func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
-	data := make(map[string]interface{})
-	data["time"] = entry.Time.Format(time.RFC3339)
-	data["level"] = entry.Level.String()
-	data["message"] = entry.Message
-
-	if entry.Prefix != "" {
-		data["prefix"] = entry.Prefix
-	}
-
-	if len(entry.Fields) > 0 {
-		for k, v := range entry.Fields {
-			data[k] = v
-		}
-	}
-
-	var output []byte
-	var err error
-
-	if f.PrettyPrint {
-		output, err = json.MarshalIndent(data, "", "  ")
-	} else {
-		output, err = json.Marshal(data)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	output = append(output, '\n')
-	return output, nil
-}
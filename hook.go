@@ -0,0 +1,46 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+)
+
+// Hook lets a Logger fan entries out to external sinks (syslog, HTTP,
+// alerting services, ...) without changing how callers write log lines.
+// Built-in implementations live under logs/hooks.
+type Hook interface {
+	// Levels returns the levels this hook wants to be fired for.
+	Levels() []Level
+	// Fire is called with the entry once it has been formatted. Hooks that
+	// do blocking I/O should hand off to a goroutine so they don't slow
+	// down the caller.
+	Fire(entry *Entry) error
+}
+
+// LevelHooks maps a level to the hooks registered for it.
+type LevelHooks map[Level][]Hook
+
+// add registers hook for every level it declares interest in.
+func (h LevelHooks) add(hook Hook) {
+	for _, level := range hook.Levels() {
+		h[level] = append(h[level], hook)
+	}
+}
+
+// fire invokes every hook registered for level. A hook error is reported to
+// os.Stderr and never interrupts the main write path.
+func (h LevelHooks) fire(level Level, entry *Entry) {
+	for _, hook := range h[level] {
+		if err := hook.Fire(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Error firing hook: %v\n", err)
+		}
+	}
+}
+
+// AddHook registers a hook that fires for every entry whose level is in
+// hook.Levels().
+func (l *Logger) AddHook(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks.add(hook)
+}
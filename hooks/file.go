@@ -0,0 +1,40 @@
+package hooks
+
+import (
+	"io"
+
+	"github.com/InfinityXOneSystems/logs"
+)
+
+// FileHook formats entries and writes them to an arbitrary io.Writer,
+// typically a *rotate.RotatingFileWriter (see logs/rotate) so hook output
+// rotates the same way the main log output does.
+type FileHook struct {
+	Writer    io.Writer
+	Formatter logs.Formatter
+	levels    []logs.Level
+}
+
+// NewFileHook returns a hook that formats entries with formatter (a
+// TextFormatter if nil) and writes them to w.
+func NewFileHook(w io.Writer, formatter logs.Formatter, levels []logs.Level) *FileHook {
+	if formatter == nil {
+		formatter = logs.NewTextFormatter()
+	}
+	return &FileHook{Writer: w, Formatter: formatter, levels: levels}
+}
+
+// Levels implements logs.Hook.
+func (h *FileHook) Levels() []logs.Level {
+	return h.levels
+}
+
+// Fire implements logs.Hook.
+func (h *FileHook) Fire(entry *logs.Entry) error {
+	b, err := h.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.Writer.Write(b)
+	return err
+}
@@ -0,0 +1,61 @@
+package hooks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/InfinityXOneSystems/logs"
+)
+
+func TestFileHookFireWritesFormattedEntry(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := logs.NewTextFormatter()
+	formatter.DisableColors = true
+	hook := NewFileHook(buf, formatter, []logs.Level{logs.INFO})
+
+	if got := hook.Levels(); len(got) != 1 || got[0] != logs.INFO {
+		t.Errorf("Expected Levels() to return [INFO], got %v", got)
+	}
+
+	entry := &logs.Entry{Level: logs.INFO, Message: "hook test"}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Fire returned unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "hook test") {
+		t.Errorf("Expected written output to contain the entry message, got: %s", output)
+	}
+}
+
+func TestFileHookDefaultsToTextFormatter(t *testing.T) {
+	hook := NewFileHook(&bytes.Buffer{}, nil, nil)
+	if _, ok := hook.Formatter.(*logs.TextFormatter); !ok {
+		t.Errorf("Expected nil formatter to default to *logs.TextFormatter, got %T", hook.Formatter)
+	}
+}
+
+func TestFileHookFireViaLoggerRoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	hookBuf := &bytes.Buffer{}
+
+	logger := logs.New()
+	logger.SetOutput(buf)
+	logger.SetLevel(logs.DEBUG)
+
+	formatter := logs.NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+	logger.AddHook(NewFileHook(hookBuf, nil, []logs.Level{logs.INFO}))
+
+	logger.Info("routed to both")
+	logger.Debug("hook not registered for this level")
+
+	if !strings.Contains(hookBuf.String(), "routed to both") {
+		t.Errorf("Expected hook output to contain the INFO entry, got: %s", hookBuf.String())
+	}
+	if strings.Contains(hookBuf.String(), "hook not registered") {
+		t.Errorf("Expected hook to be skipped for a level it didn't register, got: %s", hookBuf.String())
+	}
+}
@@ -0,0 +1,78 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/InfinityXOneSystems/logs"
+)
+
+// HTTPHook POSTs a JSON-encoded entry to URL on a background goroutine,
+// retrying with exponential backoff on failure so Fire never blocks the
+// caller's log line.
+type HTTPHook struct {
+	URL        string
+	Client     *http.Client
+	MaxRetries int
+	levels     []logs.Level
+}
+
+// NewHTTPHook returns a hook that POSTs entries at levels to url.
+func NewHTTPHook(url string, levels []logs.Level) *HTTPHook {
+	return &HTTPHook{
+		URL:        url,
+		Client:     &http.Client{Timeout: 5 * time.Second},
+		MaxRetries: 3,
+		levels:     levels,
+	}
+}
+
+// Levels implements logs.Hook.
+func (h *HTTPHook) Levels() []logs.Level {
+	return h.levels
+}
+
+// Fire implements logs.Hook.
+func (h *HTTPHook) Fire(entry *logs.Entry) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"time":    entry.Time,
+		"level":   entry.Level.String(),
+		"message": entry.Message,
+		"fields":  entry.Fields,
+		"prefix":  entry.Prefix,
+	})
+	if err != nil {
+		return err
+	}
+
+	go h.post(payload)
+	return nil
+}
+
+// post delivers payload to h.URL, retrying with exponential backoff.
+func (h *HTTPHook) post(payload []byte) {
+	backoff := 100 * time.Millisecond
+	var lastErr error
+
+	for attempt := 0; attempt <= h.MaxRetries; attempt++ {
+		resp, err := h.Client.Post(h.URL, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			lastErr = err
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode < 500 {
+				return
+			}
+			lastErr = fmt.Errorf("http hook: unexpected status %d", resp.StatusCode)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	fmt.Fprintf(os.Stderr, "Error posting log entry to %s: %v\n", h.URL, lastErr)
+}
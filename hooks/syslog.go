@@ -0,0 +1,49 @@
+// Package hooks provides built-in logs.Hook implementations for common
+// external sinks: syslog, HTTP and plain file output.
+package hooks
+
+import (
+	"log/syslog"
+
+	"github.com/InfinityXOneSystems/logs"
+)
+
+// SyslogHook forwards entries to a local or remote syslog daemon.
+type SyslogHook struct {
+	writer *syslog.Writer
+	levels []logs.Level
+}
+
+// NewSyslogHook dials network/addr (e.g. "udp", "syslog.example.com:514")
+// and returns a hook that forwards entries at levels to it. Pass network
+// and addr as "" to use the local syslog daemon.
+func NewSyslogHook(network, addr, tag string, levels []logs.Level) (*SyslogHook, error) {
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogHook{writer: w, levels: levels}, nil
+}
+
+// Levels implements logs.Hook.
+func (h *SyslogHook) Levels() []logs.Level {
+	return h.levels
+}
+
+// Fire implements logs.Hook.
+func (h *SyslogHook) Fire(entry *logs.Entry) error {
+	switch entry.Level {
+	case logs.DEBUG:
+		return h.writer.Debug(entry.Message)
+	case logs.INFO:
+		return h.writer.Info(entry.Message)
+	case logs.WARN:
+		return h.writer.Warning(entry.Message)
+	case logs.ERROR:
+		return h.writer.Err(entry.Message)
+	case logs.FATAL:
+		return h.writer.Crit(entry.Message)
+	default:
+		return h.writer.Info(entry.Message)
+	}
+}
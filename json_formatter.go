@@ -0,0 +1,160 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// jsonBufPool recycles the buffers JSONFormatter encodes its output into
+// via json.Encoder. Format still builds an intermediate map of the entry's
+// keys and encodes that map; only the output buffer is pooled.
+var jsonBufPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// JSONFormatter formats log entries as JSON.
+type JSONFormatter struct {
+	PrettyPrint bool
+
+	// TimestampFormat controls how the time field is rendered. Defaults to
+	// time.RFC3339 when empty.
+	TimestampFormat string
+
+	// FieldMap renames canonical keys ("time", "level", "message",
+	// "prefix", "func", "file", "line") to custom ones, e.g.
+	// {"time": "@timestamp", "level": "log.level"}.
+	FieldMap map[string]string
+
+	// DataKey, if set, nests entry.Fields under this key instead of
+	// flattening them into the top-level object. Without it, a user field
+	// whose name collides with a canonical key (e.g. "time") is suffixed
+	// with "_" until unique rather than overwriting it; DataKey sidesteps
+	// the collision entirely by nesting instead.
+	DataKey string
+
+	// StaticFields are merged into every entry verbatim, e.g. Logstash's
+	// {"@version": "1"}.
+	StaticFields map[string]interface{}
+}
+
+// NewJSONFormatter creates a new JSON formatter with default settings:
+// canonical key names, no nesting, RFC3339 timestamps.
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{
+		PrettyPrint:     false,
+		TimestampFormat: time.RFC3339,
+	}
+}
+
+// NewLogstashFormatter returns a JSONFormatter shaped for Logstash's json
+// codec: time/level renamed to @timestamp/log.level, RFC3339Nano
+// timestamps, user fields nested under "data" and a mandatory @version. A
+// non-empty typeTag is added as Logstash's conventional "type" field.
+func NewLogstashFormatter(typeTag string) *JSONFormatter {
+	f := &JSONFormatter{
+		TimestampFormat: time.RFC3339Nano,
+		FieldMap: map[string]string{
+			"time":  "@timestamp",
+			"level": "log.level",
+		},
+		DataKey:      "data",
+		StaticFields: map[string]interface{}{"@version": "1"},
+	}
+	if typeTag != "" {
+		f.StaticFields["type"] = typeTag
+	}
+	return f
+}
+
+// NewECSFormatter returns a JSONFormatter shaped for Elastic Common Schema
+// consumers: time/level renamed to @timestamp/log.level, RFC3339Nano
+// timestamps, user fields nested under "labels" so they never collide
+// with ECS's reserved top-level keys.
+func NewECSFormatter() *JSONFormatter {
+	return &JSONFormatter{
+		TimestampFormat: time.RFC3339Nano,
+		FieldMap: map[string]string{
+			"time":  "@timestamp",
+			"level": "log.level",
+		},
+		DataKey: "labels",
+	}
+}
+
+// key returns the output key for a canonical field name, honoring FieldMap.
+func (f *JSONFormatter) key(canonical string) string {
+	if mapped, ok := f.FieldMap[canonical]; ok {
+		return mapped
+	}
+	return canonical
+}
+
+// Format formats a log entry as JSON.
+func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+
+	data := make(map[string]interface{}, 6+len(f.StaticFields))
+	for k, v := range f.StaticFields {
+		data[k] = v
+	}
+
+	data[f.key("time")] = entry.Time.Format(timestampFormat)
+	data[f.key("level")] = entry.Level.String()
+	data[f.key("message")] = entry.Message
+
+	if entry.Prefix != "" {
+		data[f.key("prefix")] = entry.Prefix
+	}
+
+	if entry.Caller != nil {
+		data[f.key("func")] = entry.Caller.Function
+		data[f.key("file")] = entry.Caller.File
+		data[f.key("line")] = entry.Caller.Line
+	}
+
+	if len(entry.Fields) > 0 {
+		if f.DataKey != "" {
+			nested := make(map[string]interface{}, len(entry.Fields))
+			for k, v := range entry.Fields {
+				nested[k] = v
+			}
+			data[f.DataKey] = nested
+		} else {
+			for k, v := range entry.Fields {
+				// A field name colliding with a canonical key (or another
+				// field already placed, after a prior collision) would
+				// otherwise silently clobber it; suffix it until unique
+				// instead of losing data either way.
+				key := k
+				for {
+					if _, collides := data[key]; !collides {
+						break
+					}
+					key += "_"
+				}
+				data[key] = v
+			}
+		}
+	}
+
+	buf := jsonBufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer jsonBufPool.Put(buf)
+
+	enc := json.NewEncoder(buf)
+	if f.PrettyPrint {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, buf.Len())
+	copy(output, buf.Bytes())
+	return output, nil
+}
@@ -1,6 +1,7 @@
 package logs
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
@@ -44,6 +45,27 @@ type Logger struct {
 	level     Level
 	formatter Formatter
 	prefix    string
+	hooks     LevelHooks
+
+	reportCaller bool
+	ctxExtractor ContextFieldExtractor
+
+	async          bool
+	overflowPolicy OverflowPolicy
+	asyncCh        chan *Entry
+	asyncDone      chan struct{}
+	entryPool      sync.Pool
+	bufWriter      *bufio.Writer
+	dropped        int64
+
+	// shutdownMu guards the asyncCh close/send race: enqueue holds it for
+	// read while sending, shutdownAsync takes it exclusively before closing
+	// the channel, so a send is always either fully before or fully after
+	// the close, never concurrent with it.
+	shutdownMu  sync.RWMutex
+	asyncClosed bool
+
+	sampler Sampler
 }
 
 // New creates a new logger with the default configuration
@@ -53,6 +75,7 @@ func New() *Logger {
 		level:     INFO,
 		formatter: NewTextFormatter(),
 		prefix:    "",
+		hooks:     make(LevelHooks),
 	}
 }
 
@@ -84,35 +107,120 @@ func (l *Logger) SetPrefix(prefix string) {
 	l.prefix = prefix
 }
 
-// log writes a log message with the given level
-func (l *Logger) log(level Level, msg string, fields Fields) {
+// SetReportCaller controls whether log entries include the file, line and
+// function of the call site. It's disabled by default since walking the
+// stack has a cost; enable it for debugging or low-volume loggers.
+func (l *Logger) SetReportCaller(report bool) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.reportCaller = report
+}
 
+// SetSampler installs sampler, consulted on every log call (other than
+// FATAL) before formatting. A nil sampler, the default, lets everything
+// through.
+func (l *Logger) SetSampler(sampler Sampler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sampler = sampler
+}
+
+// Log builds its message lazily by calling fn only once level filtering
+// has passed, so a tight loop logging at a disabled level never pays for
+// building the string in the first place.
+func (l *Logger) Log(level Level, fn func() string) {
+	l.mu.Lock()
 	if level < l.level {
+		l.mu.Unlock()
 		return
 	}
+	l.mu.Unlock()
+
+	l.log(level, fn(), nil)
+}
 
-	entry := &Entry{
-		Time:    time.Now(),
-		Level:   level,
-		Message: msg,
-		Fields:  fields,
-		Prefix:  l.prefix,
+// log writes a log message with the given level
+func (l *Logger) log(level Level, msg string, fields Fields) {
+	entry := l.newEntry()
+	entry.Level = level
+	entry.Message = msg
+	entry.Fields = fields
+	entry.Prefix = l.prefix
+	l.write(entry)
+}
+
+// write finalizes entry (timestamp, caller) and either hands it to the
+// async worker or runs it through the formatter, hooks and output writer
+// inline. It's the single path both Logger's direct level methods and
+// *Entry (see entry.go) funnel through, so they stay in lockstep.
+func (l *Logger) write(entry *Entry) {
+	l.mu.Lock()
+	if entry.Level < l.level {
+		l.mu.Unlock()
+		return
+	}
+
+	entry.logger = l
+	entry.Time = time.Now()
+	if l.reportCaller {
+		entry.Caller = getCaller()
+	}
+	async := l.async
+	sampler := l.sampler
+	l.mu.Unlock()
+
+	if sampler != nil && entry.Level != FATAL && !sampler.Sample(entry.Level, entry.Message) {
+		if async {
+			l.releaseEntry(entry)
+		}
+		return
+	}
+
+	if async {
+		if entry.Level == FATAL {
+			// A FATAL must not wait behind queued entries that haven't
+			// reached the output yet; drain them first so ordering holds.
+			l.Flush()
+		} else {
+			l.enqueue(entry)
+			return
+		}
 	}
 
+	l.writeSync(entry)
+}
+
+// writeSync formats entry, fires hooks and writes it to the output
+// directly, bypassing the async queue.
+func (l *Logger) writeSync(entry *Entry) {
+	l.mu.Lock()
+
 	formatted, err := l.formatter.Format(entry)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error formatting log: %v\n", err)
+		l.mu.Unlock()
 		return
 	}
 
+	l.hooks.fire(entry.Level, entry)
+
 	_, err = l.output.Write(formatted)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing log: %v\n", err)
 	}
 
-	if level == FATAL {
+	isFatal := entry.Level == FATAL
+	wasAsync := l.async
+	l.mu.Unlock()
+
+	if isFatal {
+		if wasAsync {
+			// Shut the async worker down before exiting so the process
+			// doesn't race its own teardown. This must happen with l.mu
+			// released: the worker's own shutdown path needs the lock to
+			// flush its buffer.
+			l.shutdownAsync()
+		}
 		os.Exit(1)
 	}
 }
@@ -285,6 +393,18 @@ func SetFormatter(f Formatter) {
 	defaultLogger.SetFormatter(f)
 }
 
+// WithField returns a child logger carrying key=value, using the default
+// logger.
+func WithField(key string, value interface{}) *Entry {
+	return defaultLogger.WithField(key, value)
+}
+
+// WithFields returns a child logger carrying fields, using the default
+// logger.
+func WithFields(fields Fields) *Entry {
+	return defaultLogger.WithFields(fields)
+}
+
 // SetPrefix sets the logger prefix for the default logger
 func SetPrefix(prefix string) {
 	defaultLogger.SetPrefix(prefix)
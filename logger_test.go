@@ -2,8 +2,14 @@ package logs
 
 import (
 	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestLoggerLevels(t *testing.T) {
@@ -157,6 +163,280 @@ func TestJSONFormatter(t *testing.T) {
 	}
 }
 
+func TestEntryWithFields(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+
+	formatter := NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+
+	reqLog := logger.WithFields(Fields{"request_id": "abc-123", "user": "alice"})
+	reqLog.WithField("user", "bob").Info("received")
+
+	output := buf.String()
+	if !strings.Contains(output, "request_id=abc-123") {
+		t.Errorf("Expected output to contain 'request_id=abc-123', got: %s", output)
+	}
+	if !strings.Contains(output, "user=bob") {
+		t.Errorf("Expected child field to override parent, got: %s", output)
+	}
+
+	buf.Reset()
+	reqLog.Error("failed")
+	if !strings.Contains(buf.String(), "user=alice") {
+		t.Errorf("Expected original entry to keep its own fields, got: %s", buf.String())
+	}
+}
+
+func TestEntryWithContext(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+
+	formatter := NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+
+	type traceIDKey struct{}
+
+	logger.SetContextFieldExtractor(func(ctx context.Context) Fields {
+		return Fields{"trace_id": ctx.Value(traceIDKey{})}
+	})
+
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "t-1")
+	logger.WithField("user", "alice").WithContext(ctx).Info("received")
+
+	output := buf.String()
+	if !strings.Contains(output, "trace_id=t-1") {
+		t.Errorf("Expected output to contain 'trace_id=t-1', got: %s", output)
+	}
+}
+
+func TestLoggerAsync(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+
+	formatter := NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+
+	logger.SetAsync(16, Block)
+	logger.Info("queued message")
+	logger.Flush()
+
+	if !strings.Contains(buf.String(), "queued message") {
+		t.Errorf("Expected Flush to make queued message visible, got: %s", buf.String())
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close returned unexpected error: %v", err)
+	}
+}
+
+// TestLoggerFatalAsyncDoesNotDeadlock guards against a regression where
+// Fatal() in async mode held the logger's mutex while waiting for the
+// worker goroutine to shut down, and that goroutine's own shutdown path
+// needed the same mutex to flush its buffer. It re-execs the test binary
+// since Fatal calls os.Exit.
+func TestLoggerFatalAsyncDoesNotDeadlock(t *testing.T) {
+	if os.Getenv("LOGS_TEST_FATAL_ASYNC") == "1" {
+		logger := New()
+		logger.SetOutput(io.Discard)
+		logger.SetAsync(16, Block)
+		logger.Fatal("boom")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestLoggerFatalAsyncDoesNotDeadlock")
+	cmd.Env = append(os.Environ(), "LOGS_TEST_FATAL_ASYNC=1")
+
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start subprocess: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			t.Errorf("expected subprocess to exit with code 1, got: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("Fatal() in async mode did not exit within 3s, likely deadlocked")
+	}
+}
+
+// TestLoggerAsyncCloseConcurrentWithLogging guards against a regression
+// where Close() could close the async channel while another goroutine was
+// mid-send in enqueue(), panicking with "send on closed channel".
+func TestLoggerAsyncCloseConcurrentWithLogging(t *testing.T) {
+	logger := New()
+	logger.SetOutput(io.Discard)
+	logger.SetAsync(1, Block)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("concurrent message")
+		}()
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close returned unexpected error: %v", err)
+	}
+	wg.Wait()
+}
+
+func TestBasicSampler(t *testing.T) {
+	sampler := NewBasicSampler(3)
+
+	var allowed int
+	for i := 0; i < 9; i++ {
+		if sampler.Sample(INFO, "tight loop") {
+			allowed++
+		}
+	}
+
+	if allowed != 3 {
+		t.Errorf("Expected 1 in 3 entries to be sampled in (3 of 9), got %d", allowed)
+	}
+}
+
+func TestSamplerConstructorsClampDegenerateInputs(t *testing.T) {
+	burst := NewBurstSampler(0, time.Hour)
+	if !burst.Sample(INFO, "x") {
+		t.Error("Expected NewBurstSampler(0, ...) to clamp to burst=1 and allow the first entry")
+	}
+
+	tail := NewTailSampler(2, 0, time.Hour)
+	for i := 0; i < 2; i++ {
+		if !tail.Sample(INFO, "y") {
+			t.Errorf("Expected first occurrence %d to be logged", i)
+		}
+	}
+	if !tail.Sample(INFO, "y") {
+		t.Error("Expected NewTailSampler(2, 0, ...) to clamp thereafter=1 and keep logging every occurrence")
+	}
+}
+
+func TestLoggerSampler(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+
+	formatter := NewTextFormatter()
+	formatter.DisableColors = true
+	logger.SetFormatter(formatter)
+
+	logger.SetSampler(NewBasicSampler(2))
+
+	for i := 0; i < 4; i++ {
+		logger.Info("flood")
+	}
+
+	count := strings.Count(buf.String(), "flood")
+	if count != 2 {
+		t.Errorf("Expected sampler to let through 2 of 4 entries, got %d", count)
+	}
+}
+
+func TestJSONFormatterDataKeyAvoidsCollision(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+
+	formatter := NewJSONFormatter()
+	formatter.DataKey = "data"
+	logger.SetFormatter(formatter)
+
+	logger.InfoWithFields("collision test", Fields{"time": "user-supplied", "message": "user-supplied"})
+
+	output := buf.String()
+	if !strings.Contains(output, `"message":"collision test"`) {
+		t.Errorf("Expected canonical message to survive a field named 'message', got: %s", output)
+	}
+	if !strings.Contains(output, `"data":{`) {
+		t.Errorf("Expected user fields nested under 'data', got: %s", output)
+	}
+}
+
+func TestJSONFormatterDefaultAvoidsCollision(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+	logger.SetFormatter(NewJSONFormatter())
+
+	logger.InfoWithFields("collision test", Fields{"message": "user-supplied", "level": "user-supplied"})
+
+	output := buf.String()
+	if !strings.Contains(output, `"message":"collision test"`) {
+		t.Errorf("Expected canonical message to survive a field named 'message', got: %s", output)
+	}
+	if !strings.Contains(output, `"level":"INFO"`) {
+		t.Errorf("Expected canonical level to survive a field named 'level', got: %s", output)
+	}
+	if !strings.Contains(output, `"message_":"user-supplied"`) {
+		t.Errorf("Expected colliding field to survive under a suffixed key, got: %s", output)
+	}
+	if !strings.Contains(output, `"level_":"user-supplied"`) {
+		t.Errorf("Expected colliding field to survive under a suffixed key, got: %s", output)
+	}
+}
+
+func TestLogstashFormatter(t *testing.T) {
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetLevel(DEBUG)
+	logger.SetFormatter(NewLogstashFormatter("app"))
+
+	logger.Info("logstash test")
+
+	output := buf.String()
+	if !strings.Contains(output, `"@timestamp"`) {
+		t.Errorf("Expected '@timestamp' key, got: %s", output)
+	}
+	if !strings.Contains(output, `"log.level":"INFO"`) {
+		t.Errorf("Expected 'log.level' key, got: %s", output)
+	}
+	if !strings.Contains(output, `"@version":"1"`) {
+		t.Errorf("Expected '@version' key, got: %s", output)
+	}
+	if !strings.Contains(output, `"type":"app"`) {
+		t.Errorf("Expected 'type' key, got: %s", output)
+	}
+}
+
+func TestColorStripper(t *testing.T) {
+	buf := &bytes.Buffer{}
+	stripper := NewColorStripper(buf)
+
+	n, err := stripper.Write([]byte("\033[34mINFO\033[0m hello"))
+	if err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if n != len("\033[34mINFO\033[0m hello") {
+		t.Errorf("Expected Write to report the input length, got %d", n)
+	}
+
+	if buf.String() != "INFO hello" {
+		t.Errorf("Expected ANSI codes stripped, got: %q", buf.String())
+	}
+}
+
 func TestDefaultLogger(t *testing.T) {
 	buf := &bytes.Buffer{}
 	SetOutput(buf)
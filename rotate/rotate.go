@@ -0,0 +1,275 @@
+// Package rotate provides a size/time based rotating file writer that can
+// be passed to Logger.SetOutput, turning the common
+// os.Create("combined.log") pattern into a one-liner with sane defaults.
+package rotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingFileWriter is an io.Writer that rotates Filename by size and/or
+// a daily wall-clock trigger, optionally gzip-compressing rotated files
+// and pruning old ones by age or count. It guards its own state with a
+// mutex independent of the Logger's, since the two are separate concerns.
+type RotatingFileWriter struct {
+	Filename     string
+	MaxSizeBytes int64
+	MaxAge       time.Duration
+	MaxBackups   int
+	Compress     bool
+	LocalTime    bool
+	// RotateAt triggers a rotation once a day at the given "HH:MM" wall
+	// clock time, independent of MaxSizeBytes. Empty disables it.
+	RotateAt string
+
+	mu         sync.Mutex
+	file       *os.File
+	size       int64
+	nextRotate time.Time
+}
+
+// NewRotatingFileWriter opens (creating if necessary) filename and returns
+// a writer with default options: no size or age limit, no compression.
+// Set the exported fields before first Write to customize rotation.
+func NewRotatingFileWriter(filename string) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{Filename: filename}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write implements io.Writer, rotating first if needed.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Reopen closes and reopens Filename, for use after an external tool
+// (logrotate, a SIGHUP handler) has already moved the file aside.
+func (w *RotatingFileWriter) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.open()
+}
+
+// Close closes the underlying file.
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *RotatingFileWriter) open() error {
+	if w.file != nil {
+		w.file.Close()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(w.Filename), 0o755); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("rotate: create log dir: %w", err)
+	}
+
+	f, err := os.OpenFile(w.Filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("rotate: open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("rotate: stat log file: %w", err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.nextRotate = w.computeNextRotate()
+	return nil
+}
+
+func (w *RotatingFileWriter) shouldRotate(nextWrite int64) bool {
+	if w.MaxSizeBytes > 0 && w.size+nextWrite > w.MaxSizeBytes {
+		return true
+	}
+	if w.RotateAt != "" && w.nextRotate.IsZero() {
+		// RotateAt may have been set after open() ran (e.g. after
+		// NewRotatingFileWriter), so schedule the first trigger lazily.
+		w.nextRotate = w.computeNextRotate()
+	}
+	if w.RotateAt != "" && !w.nextRotate.IsZero() && w.now().After(w.nextRotate) {
+		return true
+	}
+	return false
+}
+
+func (w *RotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("rotate: close log file: %w", err)
+	}
+	w.file = nil
+
+	rotated := fmt.Sprintf("%s-%s.log", strings.TrimSuffix(w.Filename, filepath.Ext(w.Filename)), w.now().Format("20060102-150405"))
+	if err := os.Rename(w.Filename, rotated); err != nil {
+		return fmt.Errorf("rotate: rename log file: %w", err)
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	if w.Compress {
+		go compressAndRemove(rotated)
+	}
+
+	go w.prune()
+
+	return nil
+}
+
+// now returns the rotation-relevant current time, honoring LocalTime.
+func (w *RotatingFileWriter) now() time.Time {
+	if w.LocalTime {
+		return time.Now()
+	}
+	return time.Now().UTC()
+}
+
+// computeNextRotate parses RotateAt ("HH:MM") and returns the next
+// occurrence of that wall-clock time, or the zero Time if RotateAt is unset
+// or malformed.
+func (w *RotatingFileWriter) computeNextRotate() time.Time {
+	if w.RotateAt == "" {
+		return time.Time{}
+	}
+
+	parts := strings.SplitN(w.RotateAt, ":", 2)
+	if len(parts) != 2 {
+		return time.Time{}
+	}
+	hour, err1 := strconv.Atoi(parts[0])
+	minute, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return time.Time{}
+	}
+
+	now := w.now()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
+
+// compressAndRemove gzips filename to filename+".gz" and removes the
+// original. Errors are reported to stderr since this runs on its own
+// goroutine, detached from the Write call that triggered it.
+func compressAndRemove(filename string) {
+	src, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rotate: open %s for compression: %v\n", filename, err)
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(filename + ".gz")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rotate: create %s.gz: %v\n", filename, err)
+		return
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		fmt.Fprintf(os.Stderr, "rotate: compress %s: %v\n", filename, err)
+		gz.Close()
+		dst.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "rotate: finalize %s.gz: %v\n", filename, err)
+	}
+	dst.Close()
+
+	if err := os.Remove(filename); err != nil {
+		fmt.Fprintf(os.Stderr, "rotate: remove %s: %v\n", filename, err)
+	}
+}
+
+// prune removes rotated backups beyond MaxBackups or older than MaxAge. It
+// runs on its own goroutine since it only touches files this writer has
+// already rotated away from.
+func (w *RotatingFileWriter) prune() {
+	if w.MaxBackups <= 0 && w.MaxAge <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(w.Filename)
+	base := strings.TrimSuffix(filepath.Base(w.Filename), filepath.Ext(w.Filename))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rotate: list %s: %v\n", dir, err)
+		return
+	}
+
+	var backups []os.FileInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), base+"-") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, info)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().Before(backups[j].ModTime())
+	})
+
+	cutoff := time.Time{}
+	if w.MaxAge > 0 {
+		cutoff = time.Now().Add(-w.MaxAge)
+	}
+
+	keepFrom := 0
+	if w.MaxBackups > 0 && len(backups) > w.MaxBackups {
+		keepFrom = len(backups) - w.MaxBackups
+	}
+
+	for i, info := range backups {
+		expired := !cutoff.IsZero() && info.ModTime().Before(cutoff)
+		if i < keepFrom || expired {
+			os.Remove(filepath.Join(dir, info.Name()))
+		}
+	}
+}
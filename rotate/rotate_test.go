@@ -0,0 +1,97 @@
+package rotate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileWriterRotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+
+	w, err := NewRotatingFileWriter(filename)
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter returned error: %v", err)
+	}
+	defer w.Close()
+	w.MaxSizeBytes = 10
+
+	if _, err := w.Write([]byte("12345")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	// This write pushes size past MaxSizeBytes, so it should land in a
+	// freshly rotated file rather than the original.
+	if _, err := w.Write([]byte("1234567890")); err != nil {
+		t.Fatalf("second Write returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+
+	var sawOriginal, sawRotated bool
+	for _, e := range entries {
+		switch {
+		case e.Name() == "app.log":
+			sawOriginal = true
+		case strings.HasPrefix(e.Name(), "app-") && strings.HasSuffix(e.Name(), ".log"):
+			sawRotated = true
+		}
+	}
+
+	if !sawOriginal {
+		t.Errorf("Expected a fresh app.log after rotation, found: %v", entries)
+	}
+	if !sawRotated {
+		t.Errorf("Expected a rotated backup file, found: %v", entries)
+	}
+}
+
+func TestRotatingFileWriterPrunesOldBackups(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+
+	w, err := NewRotatingFileWriter(filename)
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter returned error: %v", err)
+	}
+	defer w.Close()
+	w.MaxSizeBytes = 1
+	w.MaxBackups = 1
+
+	for i := 0; i < 2; i++ {
+		if _, err := w.Write([]byte("xx")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+		// Rotated filenames have second precision; space rotations out so
+		// each gets a distinct name instead of overwriting the last.
+		time.Sleep(1100 * time.Millisecond)
+	}
+
+	var backups int
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir returned error: %v", err)
+		}
+		backups = 0
+		for _, e := range entries {
+			if e.Name() != "app.log" {
+				backups++
+			}
+		}
+		if backups <= w.MaxBackups || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if backups > w.MaxBackups {
+		t.Errorf("Expected prune (async, on its own goroutine) to keep at most %d backup(s), found %d", w.MaxBackups, backups)
+	}
+}
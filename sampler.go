@@ -0,0 +1,131 @@
+package logs
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides whether a given (level, msg) log call is actually
+// written. It's consulted before formatting, so a sampled-out entry costs
+// almost nothing: no hooks fire and nothing reaches the output writer.
+type Sampler interface {
+	Sample(level Level, msg string) bool
+}
+
+// sampleKey reduces level and msg to a single key for the sync.Map that
+// BurstSampler and TailSampler each use to track per-(level,msg) state.
+// Each sampler keeps one sync.Map; it is not split into shards.
+func sampleKey(level Level, msg string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte{byte(level)})
+	h.Write([]byte(msg))
+	return h.Sum64()
+}
+
+// BasicSampler logs 1 out of every N entries, counted per level.
+type BasicSampler struct {
+	n        int64
+	counters [5]int64 // one per Level: DEBUG..FATAL
+}
+
+// NewBasicSampler returns a Sampler that lets through 1 of every n entries
+// for each level. n < 1 is treated as 1 (no sampling).
+func NewBasicSampler(n int) *BasicSampler {
+	if n < 1 {
+		n = 1
+	}
+	return &BasicSampler{n: int64(n)}
+}
+
+// Sample implements Sampler.
+func (s *BasicSampler) Sample(level Level, msg string) bool {
+	count := atomic.AddInt64(&s.counters[level], 1)
+	return (count-1)%s.n == 0
+}
+
+// burstBucket is the token-bucket state kept per (level,msg) key.
+type burstBucket struct {
+	tokens     int64
+	windowEnds int64 // UnixNano
+}
+
+// BurstSampler lets burst entries per (level,msg) through every per
+// duration, refilling the bucket once the window elapses.
+type BurstSampler struct {
+	burst   int64
+	per     time.Duration
+	buckets sync.Map // uint64 -> *burstBucket
+}
+
+// NewBurstSampler returns a token-bucket Sampler allowing burst entries of
+// a given (level,msg) through every per. burst < 1 is treated as 1.
+func NewBurstSampler(burst int, per time.Duration) *BurstSampler {
+	if burst < 1 {
+		burst = 1
+	}
+	return &BurstSampler{burst: int64(burst), per: per}
+}
+
+// Sample implements Sampler.
+func (s *BurstSampler) Sample(level Level, msg string) bool {
+	key := sampleKey(level, msg)
+	now := time.Now().UnixNano()
+
+	v, _ := s.buckets.LoadOrStore(key, &burstBucket{tokens: s.burst, windowEnds: now + int64(s.per)})
+	bucket := v.(*burstBucket)
+
+	if now > atomic.LoadInt64(&bucket.windowEnds) {
+		atomic.StoreInt64(&bucket.windowEnds, now+int64(s.per))
+		atomic.StoreInt64(&bucket.tokens, s.burst)
+	}
+
+	return atomic.AddInt64(&bucket.tokens, -1) >= 0
+}
+
+// tailBucket is the occurrence-counter state kept per (level,msg) key.
+type tailBucket struct {
+	count      int64
+	windowEnds int64 // UnixNano
+}
+
+// TailSampler logs the first `first` occurrences of a (level,msg) pair
+// within interval, then 1 out of every `thereafter` after that.
+type TailSampler struct {
+	first      int64
+	thereafter int64
+	interval   time.Duration
+	buckets    sync.Map // uint64 -> *tailBucket
+}
+
+// NewTailSampler returns a Sampler that logs the first occurrences of a
+// (level,msg) pair, then 1 per thereafter occurrences, resetting every
+// interval. thereafter < 1 is treated as 1 (no sampling past the first
+// occurrences).
+func NewTailSampler(first, thereafter int, interval time.Duration) *TailSampler {
+	if thereafter < 1 {
+		thereafter = 1
+	}
+	return &TailSampler{first: int64(first), thereafter: int64(thereafter), interval: interval}
+}
+
+// Sample implements Sampler.
+func (s *TailSampler) Sample(level Level, msg string) bool {
+	key := sampleKey(level, msg)
+	now := time.Now().UnixNano()
+
+	v, _ := s.buckets.LoadOrStore(key, &tailBucket{windowEnds: now + int64(s.interval)})
+	bucket := v.(*tailBucket)
+
+	if now > atomic.LoadInt64(&bucket.windowEnds) {
+		atomic.StoreInt64(&bucket.windowEnds, now+int64(s.interval))
+		atomic.StoreInt64(&bucket.count, 0)
+	}
+
+	count := atomic.AddInt64(&bucket.count, 1)
+	if count <= s.first {
+		return true
+	}
+	return (count-s.first)%s.thereafter == 0
+}